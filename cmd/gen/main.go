@@ -0,0 +1,82 @@
+// Command gen generates a Go source file containing a User-like struct and
+// its matching Process<Type>s filter from a JSON Schema draft 2020-12
+// document.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Else00/zed-jinja-universal/schemagen"
+)
+
+// commentList collects repeated -comment flags, in the order given, as
+// lines prepended to the generated file's header comment.
+type commentList []string
+
+func (c *commentList) String() string {
+	return fmt.Sprint([]string(*c))
+}
+
+func (c *commentList) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
+func main() {
+	var (
+		schemaPath   string
+		outPath      string
+		pkgName      string
+		topLevelName string
+		comments     commentList
+	)
+	flag.StringVar(&schemaPath, "s", "", "path to the JSON Schema document")
+	flag.StringVar(&outPath, "o", "", "path to write the generated Go file")
+	flag.StringVar(&pkgName, "pkg", "main", "package name for the generated file")
+	flag.StringVar(&topLevelName, "top-level-name", "", "override the generated type name (defaults to the schema's title)")
+	flag.Var(&comments, "comment", "file-header comment line; may be repeated")
+	flag.Parse()
+
+	if err := run(schemaPath, outPath, pkgName, topLevelName, comments); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, outPath, pkgName, topLevelName string, comments commentList) error {
+	if schemaPath == "" || outPath == "" {
+		return fmt.Errorf("-s and -o are required")
+	}
+
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return fmt.Errorf("read schema: %w", err)
+	}
+
+	schema, err := schemagen.ParseSchema(data)
+	if err != nil {
+		return err
+	}
+
+	file, err := schemagen.Generate(schema, schemagen.Options{
+		Package:       pkgName,
+		TopLevelName:  topLevelName,
+		HeaderComment: comments,
+	})
+	if err != nil {
+		return fmt.Errorf("generate: %w", err)
+	}
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", outPath, err)
+	}
+	defer out.Close()
+
+	if err := file.Render(out); err != nil {
+		return fmt.Errorf("render %s: %w", outPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// === XDG-BASED YAML CONFIG ===
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Config holds the filter rules ProcessUsers applies on top of struct-tag
+// validation, loaded from $XDG_CONFIG_HOME/zed-jinja-universal/config.yaml.
+type Config struct {
+	MinAge int
+	MaxAge int
+	// RequireEmail gates presence; User's `validate:"email"` tag only
+	// checks format and leaves a blank email alone, so this is the only
+	// thing rejecting users with no email at all.
+	RequireEmail     bool
+	GreetingTemplate string
+}
+
+// defaultConfig matches the module's original behavior: users 18 and over,
+// no upper age bound, email optional, greeted via Greet().
+func defaultConfig() *Config {
+	return &Config{
+		MinAge:           18,
+		MaxAge:           0,
+		RequireEmail:     false,
+		GreetingTemplate: "{{ user.Greet }}",
+	}
+}
+
+// allows reports whether user passes cfg's filter rules. A zero MaxAge means
+// no upper bound.
+func (cfg *Config) allows(user User) bool {
+	if cfg == nil {
+		return true
+	}
+	if user.Age < cfg.MinAge {
+		return false
+	}
+	if cfg.MaxAge > 0 && user.Age > cfg.MaxAge {
+		return false
+	}
+	if cfg.RequireEmail && user.Email == "" {
+		return false
+	}
+	return true
+}
+
+// configPath resolves the config file location, honoring XDG_CONFIG_HOME
+// and falling back to ~/.config, matching the pattern used by the trending
+// project.
+func configPath() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("config: resolve home directory: %w", err)
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "zed-jinja-universal", "config.yaml"), nil
+}
+
+// LoadConfig reads the YAML config file, auto-creating it with
+// defaultConfig's values on first run so the module works without a
+// pre-existing config file.
+func LoadConfig() (*Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		cfg := defaultConfig()
+		if err := writeConfig(path, cfg); err != nil {
+			return nil, fmt.Errorf("config: write default config: %w", err)
+		}
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+	cfg, err := parseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func writeConfig(path string, cfg *Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(marshalConfig(cfg)), 0o644)
+}
+
+func marshalConfig(cfg *Config) string {
+	return fmt.Sprintf(
+		"min_age: %d\nmax_age: %d\nrequire_email: %t\ngreeting_template: %q\n",
+		cfg.MinAge, cfg.MaxAge, cfg.RequireEmail, cfg.GreetingTemplate,
+	)
+}
+
+// parseConfig reads the handful of scalar keys config.yaml declares; any
+// key it doesn't recognize is ignored, and any key not present keeps its
+// defaultConfig value.
+func parseConfig(data []byte) (*Config, error) {
+	cfg := defaultConfig()
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "min_age":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid min_age %q: %w", value, err)
+			}
+			cfg.MinAge = n
+		case "max_age":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_age %q: %w", value, err)
+			}
+			cfg.MaxAge = n
+		case "require_email":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid require_email %q: %w", value, err)
+			}
+			cfg.RequireEmail = b
+		case "greeting_template":
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				cfg.GreetingTemplate = unquoted
+			} else {
+				cfg.GreetingTemplate = value
+			}
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestRequireEmailGatesBlankEmailRegardlessOfFormatCheck(t *testing.T) {
+	user := User{Name: "Ada", Email: "", Age: 30}
+
+	lenient := &Config{MinAge: 18, GreetingTemplate: "{{ user.Greet }}", RequireEmail: false}
+	if got := ProcessUsers([]User{user}, lenient); len(got) != 1 {
+		t.Errorf("RequireEmail:false should let a blank-email user through, got %v", got)
+	}
+
+	strict := &Config{MinAge: 18, GreetingTemplate: "{{ user.Greet }}", RequireEmail: true}
+	if got := ProcessUsers([]User{user}, strict); len(got) != 0 {
+		t.Errorf("RequireEmail:true should reject a blank-email user, got %v", got)
+	}
+}
+
+func TestParseConfigRejectsMalformedScalars(t *testing.T) {
+	cases := []string{
+		"min_age: not-a-number\n",
+		"max_age: not-a-number\n",
+		"require_email: not-a-bool\n",
+	}
+	for _, data := range cases {
+		if _, err := parseConfig([]byte(data)); err == nil {
+			t.Errorf("parseConfig(%q) should have errored", data)
+		}
+	}
+}
+
+func TestParseConfigAppliesDefaultsForMissingKeys(t *testing.T) {
+	cfg, err := parseConfig([]byte("min_age: 21\n"))
+	if err != nil {
+		t.Fatalf("parseConfig: %v", err)
+	}
+	if cfg.MinAge != 21 {
+		t.Errorf("MinAge = %d, want 21", cfg.MinAge)
+	}
+	if cfg.GreetingTemplate != defaultConfig().GreetingTemplate {
+		t.Errorf("GreetingTemplate should keep its default when unset, got %q", cfg.GreetingTemplate)
+	}
+}
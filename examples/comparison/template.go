@@ -0,0 +1,227 @@
+// === JINJA-COMPATIBLE TEMPLATE PATH ===
+// This file mirrors ProcessUsers in test.go but routes each user through a
+// small Jinja2-compatible template engine instead of compiled Go code, so
+// the two paths can be exercised against the same fixtures.
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// templateContext is the set of values a template string can reference.
+// Name, Email and Age mirror the exported User fields; Greet exposes the
+// Greet method so templates can call `{{ user.Greet }}`.
+type templateContext struct {
+	Name  string
+	Email string
+	Age   int
+	Greet string
+}
+
+func newTemplateContext(u User) templateContext {
+	return templateContext{
+		Name:  u.Name,
+		Email: u.Email,
+		Age:   u.Age,
+		Greet: u.Greet(),
+	}
+}
+
+var (
+	forTag  = regexp.MustCompile(`(?s)\{%\s*for\s+(\w+)\s+in\s+(\w+)\s*%\}(.*?)\{%\s*endfor\s*%\}`)
+	ifTag   = regexp.MustCompile(`(?s)\{%\s*if\s+([^%]+?)\s*%\}(.*?)\{%\s*endif\s*%\}`)
+	varTag  = regexp.MustCompile(`\{\{\s*([^}]+?)\s*\}\}`)
+	fieldRe = regexp.MustCompile(`^\s*(\w+)(?:\.(\w+))?\s*$`)
+)
+
+// renderTemplate evaluates a Jinja2-compatible template string (variable
+// interpolation, `{% if %}` blocks and filters such as `|upper` and
+// `|default(...)`) against a single user's context. `{% for %}` loops are
+// supported for the special case of iterating the single-element "users"
+// collection that ProcessUsersTemplated hands each user through, so a
+// template can be shared between list and single-user rendering.
+func renderTemplate(tmpl string, ctx templateContext) (string, error) {
+	out := tmpl
+
+	out = forTag.ReplaceAllStringFunc(out, func(block string) string {
+		m := forTag.FindStringSubmatch(block)
+		loopVar, collection, body := m[1], m[2], m[3]
+		if collection != "users" && collection != "user" {
+			return ""
+		}
+		rendered, err := renderTemplate(strings.ReplaceAll(body, loopVar+".", "user."), ctx)
+		if err != nil {
+			return ""
+		}
+		return rendered
+	})
+
+	var evalErr error
+	out = ifTag.ReplaceAllStringFunc(out, func(block string) string {
+		m := ifTag.FindStringSubmatch(block)
+		cond, body := m[1], m[2]
+		ok, err := evalCondition(cond, ctx)
+		if err != nil {
+			evalErr = err
+			return ""
+		}
+		if !ok {
+			return ""
+		}
+		return body
+	})
+	if evalErr != nil {
+		return "", evalErr
+	}
+
+	out = varTag.ReplaceAllStringFunc(out, func(expr string) string {
+		m := varTag.FindStringSubmatch(expr)
+		rendered, err := evalExpression(m[1], ctx)
+		if err != nil {
+			evalErr = err
+			return ""
+		}
+		return rendered
+	})
+	if evalErr != nil {
+		return "", evalErr
+	}
+
+	return out, nil
+}
+
+// evalExpression resolves a `user.Field|filter(...)` expression to a string.
+func evalExpression(expr string, ctx templateContext) (string, error) {
+	parts := strings.Split(expr, "|")
+	value, err := lookupField(strings.TrimSpace(parts[0]), ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, filter := range parts[1:] {
+		value, err = applyFilter(strings.TrimSpace(filter), value)
+		if err != nil {
+			return "", err
+		}
+	}
+	return value, nil
+}
+
+func lookupField(ref string, ctx templateContext) (string, error) {
+	m := fieldRe.FindStringSubmatch(ref)
+	if m == nil {
+		return "", fmt.Errorf("jinja: invalid field reference %q", ref)
+	}
+	root, field := m[1], m[2]
+	if root != "user" {
+		return "", fmt.Errorf("jinja: unknown variable %q", root)
+	}
+	switch field {
+	case "", "Name":
+		return ctx.Name, nil
+	case "Email":
+		return ctx.Email, nil
+	case "Age":
+		return fmt.Sprintf("%d", ctx.Age), nil
+	case "Greet":
+		return ctx.Greet, nil
+	default:
+		return "", fmt.Errorf("jinja: unknown field %q", field)
+	}
+}
+
+func applyFilter(filter string, value string) (string, error) {
+	name := filter
+	arg := ""
+	if i := strings.Index(filter, "("); i != -1 && strings.HasSuffix(filter, ")") {
+		name = filter[:i]
+		arg = strings.Trim(filter[i+1:len(filter)-1], `"'`)
+	}
+	switch name {
+	case "upper":
+		return strings.ToUpper(value), nil
+	case "lower":
+		return strings.ToLower(value), nil
+	case "default":
+		if value == "" {
+			return arg, nil
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("jinja: unknown filter %q", name)
+	}
+}
+
+// evalCondition supports the handful of comparisons ProcessUsersTemplated's
+// fixtures need: `user.Field`, `user.Field == "literal"` and
+// `user.Field >= N`.
+func evalCondition(cond string, ctx templateContext) (bool, error) {
+	cond = strings.TrimSpace(cond)
+	for _, op := range []string{"==", ">=", "<=", ">", "<"} {
+		if idx := strings.Index(cond, op); idx != -1 {
+			left, err := lookupField(strings.TrimSpace(cond[:idx]), ctx)
+			if err != nil {
+				return false, err
+			}
+			right := strings.Trim(strings.TrimSpace(cond[idx+len(op):]), `"'`)
+			return compare(left, right, op)
+		}
+	}
+	value, err := lookupField(cond, ctx)
+	if err != nil {
+		return false, err
+	}
+	return value != "" && value != "0", nil
+}
+
+func compare(left, right, op string) (bool, error) {
+	var l, r int
+	if _, err := fmt.Sscanf(left, "%d", &l); err == nil {
+		if _, err := fmt.Sscanf(right, "%d", &r); err == nil {
+			switch op {
+			case "==":
+				return l == r, nil
+			case ">=":
+				return l >= r, nil
+			case "<=":
+				return l <= r, nil
+			case ">":
+				return l > r, nil
+			case "<":
+				return l < r, nil
+			}
+		}
+	}
+	if op != "==" {
+		return false, fmt.Errorf("jinja: cannot compare %q %s %q", left, op, right)
+	}
+	return left == right, nil
+}
+
+// ProcessUsersTemplated mirrors ProcessUsers but renders each eligible user
+// through an explicit Jinja2-compatible template string instead of
+// cfg.GreetingTemplate, giving callers a single entry point for both the
+// compiled Go path and the templated path against identical fixtures. It
+// validates and filters users the same way ProcessUsers does, falling back
+// to defaultConfig() when cfg is nil.
+func ProcessUsersTemplated(users []User, tmpl string, cfg *Config) ([]string, error) {
+	if cfg == nil {
+		cfg = defaultConfig()
+	}
+	var results []string
+	for _, user := range users {
+		if errs := ValidateUser(user, nil); len(errs) != 0 {
+			continue
+		}
+		if !cfg.allows(user) {
+			continue
+		}
+		rendered, err := renderTemplate(tmpl, newTemplateContext(user))
+		if err != nil {
+			return nil, fmt.Errorf("ProcessUsersTemplated: %w", err)
+		}
+		results = append(results, rendered)
+	}
+	return results, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestRenderTemplateFiltersAndConditionals(t *testing.T) {
+	ctx := templateContext{Name: "ada", Email: "", Age: 30, Greet: "Hello, ada!"}
+
+	out, err := renderTemplate(`{{ user.Name|upper }} is {% if user.Age >= 18 %}an adult{% endif %}`, ctx)
+	if err != nil {
+		t.Fatalf("renderTemplate: %v", err)
+	}
+	if want := "ADA is an adult"; out != want {
+		t.Errorf("renderTemplate() = %q, want %q", out, want)
+	}
+}
+
+func TestRenderTemplateDefaultFilter(t *testing.T) {
+	ctx := templateContext{Name: "ada", Email: "", Age: 30, Greet: "Hello, ada!"}
+
+	out, err := renderTemplate(`{{ user.Email|default("no email") }}`, ctx)
+	if err != nil {
+		t.Fatalf("renderTemplate: %v", err)
+	}
+	if want := "no email"; out != want {
+		t.Errorf("renderTemplate() = %q, want %q", out, want)
+	}
+}
+
+func TestRenderTemplateUnknownFilterErrors(t *testing.T) {
+	ctx := templateContext{Name: "ada"}
+	if _, err := renderTemplate(`{{ user.Name|shout }}`, ctx); err == nil {
+		t.Fatal("expected an error for an unknown filter")
+	}
+}
+
+func TestRenderTemplateUnknownFieldErrors(t *testing.T) {
+	ctx := templateContext{Name: "ada"}
+	if _, err := renderTemplate(`{{ user.Nickname }}`, ctx); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
+
+func TestProcessUsersTemplatedAppliesValidationAndConfig(t *testing.T) {
+	users := []User{
+		{Name: "Adult", Email: "not-an-email", Age: 30},
+		{Name: "Valid", Email: "valid@example.com", Age: 30},
+		{Name: "Minor", Email: "minor@example.com", Age: 12},
+	}
+
+	results, err := ProcessUsersTemplated(users, "{{ user.Greet }}", nil)
+	if err != nil {
+		t.Fatalf("ProcessUsersTemplated: %v", err)
+	}
+	if len(results) != 1 || results[0] != "Hello, Valid!" {
+		t.Errorf("ProcessUsersTemplated() = %v, want only the greeting of the user passing both validation and the default config", results)
+	}
+}
@@ -1,27 +1,79 @@
-// === PURE GO (no jinja) ===
+// === GO, RENDERED VIA CONFIG-DRIVEN JINJA TEMPLATE ===
 package main
 
 import (
     "fmt"
-    "strings"
 )
 
 type User struct {
-    Name  string
-    Email string
-    Age   int
+    Name  string `validate:"required" msg:"err_name_required"`
+    Email string `validate:"email" msg:"err_email_invalid"`
+    Age   int    `validate:"gte=0,lte=150" msg:"err_age_range"`
 }
 
 func (u *User) Greet() string {
     return fmt.Sprintf("Hello, %s!", u.Name)
 }
 
-func ProcessUsers(users []User) []string {
+// ProcessUsers validates each user against the `validate` struct tags, applies
+// cfg's filter rules (min_age, max_age, require_email) and renders
+// cfg.GreetingTemplate for every user that survives both; use
+// ProcessUsersStrict to also collect the validation errors, or
+// ProcessUsersDefault to run with the default config.
+func ProcessUsers(users []User, cfg *Config) []string {
+    if cfg == nil {
+        cfg = defaultConfig()
+    }
     var results []string
     for _, user := range users {
-        if user.Age >= 18 {
-            results = append(results, user.Greet())
+        if errs := ValidateUser(user, nil); len(errs) != 0 {
+            continue
+        }
+        if !cfg.allows(user) {
+            continue
         }
+        greeting, err := renderTemplate(cfg.GreetingTemplate, newTemplateContext(user))
+        if err != nil {
+            continue
+        }
+        results = append(results, greeting)
     }
     return results
 }
+
+// ProcessUsersDefault runs ProcessUsers against the built-in default config
+// (age 18+, email optional) for callers that don't need custom filter
+// rules. Note this is stricter than the module's pre-validation behavior:
+// ValidateUser now also requires a non-empty Name and rejects a malformed
+// Email, so such a user is dropped even though age alone would have passed
+// it before. A blank Email still passes, since RequireEmail is off by default.
+func ProcessUsersDefault(users []User) []string {
+    return ProcessUsers(users, defaultConfig())
+}
+
+// ProcessUsersStrict behaves like ProcessUsers, applying cfg's filter rules
+// and greeting template, but also returns a field->message map for every
+// user that failed validation, keyed by the user's index in the input
+// slice.
+func ProcessUsersStrict(users []User, cfg *Config) ([]string, map[int]map[string]string) {
+    if cfg == nil {
+        cfg = defaultConfig()
+    }
+    var results []string
+    failures := make(map[int]map[string]string)
+    for i, user := range users {
+        if errs := ValidateUser(user, nil); len(errs) != 0 {
+            failures[i] = errs
+            continue
+        }
+        if !cfg.allows(user) {
+            continue
+        }
+        greeting, err := renderTemplate(cfg.GreetingTemplate, newTemplateContext(user))
+        if err != nil {
+            continue
+        }
+        results = append(results, greeting)
+    }
+    return results, failures
+}
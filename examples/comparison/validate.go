@@ -0,0 +1,129 @@
+// === STRUCT-TAG-DRIVEN VALIDATION ===
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultMessages is the fallback English message table used whenever a
+// caller doesn't supply its own (or is missing an entry for a given key).
+var defaultMessages = map[string]string{
+	"err_name_required": "name is required",
+	"err_email_invalid": "email must be a valid address",
+	"err_age_range":     "age must be between 0 and 150",
+}
+
+// emailPattern is a deliberately permissive check; it exists to catch
+// obviously malformed input, not to fully validate RFC 5322 addresses.
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// CustomValidator is a user-registered rule. It receives the field's value
+// and the rule's argument (the text after `=`, or "" for bare rules like
+// `required`) and reports whether the value satisfies the rule.
+type CustomValidator func(value reflect.Value, arg string) bool
+
+// customValidators holds rules registered via RegisterValidator, keyed by
+// the name used in the `validate` tag.
+var customValidators = map[string]CustomValidator{}
+
+// RegisterValidator adds a custom validation rule usable in `validate` tags
+// alongside the built-in ones (required, email, min, max, gte, lte).
+func RegisterValidator(name string, fn CustomValidator) {
+	customValidators[name] = fn
+}
+
+// ValidateUser walks u's `validate` struct tags via reflection and returns a
+// field name -> message map for every rule that failed. messages looks up
+// the message key named by each field's `msg` tag; any key missing from
+// messages falls back to defaultMessages.
+func ValidateUser(u User, messages map[string]string) map[string]string {
+	errs := make(map[string]string)
+	v := reflect.ValueOf(u)
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		rules := field.Tag.Get("validate")
+		if rules == "" {
+			continue
+		}
+		value := v.Field(i)
+		if ok := runRules(value, rules); !ok {
+			key := field.Tag.Get("msg")
+			errs[field.Name] = lookupMessage(key, messages)
+		}
+	}
+	return errs
+}
+
+func lookupMessage(key string, messages map[string]string) string {
+	if messages != nil {
+		if msg, ok := messages[key]; ok {
+			return msg
+		}
+	}
+	if msg, ok := defaultMessages[key]; ok {
+		return msg
+	}
+	return key
+}
+
+// runRules evaluates every comma-separated rule in tag against value and
+// reports whether all of them passed.
+func runRules(value reflect.Value, tag string) bool {
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		if !runRule(value, name, arg) {
+			return false
+		}
+	}
+	return true
+}
+
+func runRule(value reflect.Value, name, arg string) bool {
+	switch name {
+	case "required":
+		return !value.IsZero()
+	case "email":
+		// An empty value is left to a separate `required` rule; `email`
+		// only judges the format of a value that's actually present, so
+		// presence gates like Config.RequireEmail stay meaningful.
+		if value.String() == "" {
+			return true
+		}
+		return emailPattern.MatchString(value.String())
+	case "min":
+		return numericRule(value, arg, func(v, n float64) bool { return v >= n })
+	case "max":
+		return numericRule(value, arg, func(v, n float64) bool { return v <= n })
+	case "gte":
+		return numericRule(value, arg, func(v, n float64) bool { return v >= n })
+	case "lte":
+		return numericRule(value, arg, func(v, n float64) bool { return v <= n })
+	default:
+		if fn, ok := customValidators[name]; ok {
+			return fn(value, arg)
+		}
+		return true
+	}
+}
+
+func numericRule(value reflect.Value, arg string, cmp func(v, n float64) bool) bool {
+	n, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return true
+	}
+	var v float64
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = float64(value.Int())
+	case reflect.Float32, reflect.Float64:
+		v = value.Float()
+	default:
+		v = float64(value.Len())
+	}
+	return cmp(v, n)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateUserReportsEachFailedField(t *testing.T) {
+	errs := ValidateUser(User{Name: "", Email: "not-an-email", Age: 200}, nil)
+
+	for _, field := range []string{"Name", "Email", "Age"} {
+		if _, ok := errs[field]; !ok {
+			t.Errorf("expected an error for field %q, got %v", field, errs)
+		}
+	}
+}
+
+func TestValidateUserPassesValidUser(t *testing.T) {
+	errs := ValidateUser(User{Name: "Ada", Email: "ada@example.com", Age: 30}, nil)
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateUserCustomMessages(t *testing.T) {
+	errs := ValidateUser(User{Name: "", Email: "ada@example.com", Age: 30}, map[string]string{
+		"err_name_required": "nombre requerido",
+	})
+	if errs["Name"] != "nombre requerido" {
+		t.Errorf("Name error = %q, want custom message", errs["Name"])
+	}
+}
+
+func TestRegisterValidatorCustomRule(t *testing.T) {
+	RegisterValidator("even_length", func(value reflect.Value, arg string) bool {
+		return value.Len()%2 == 0
+	})
+	defer delete(customValidators, "even_length")
+
+	if !runRule(reflect.ValueOf("abcd"), "even_length", "") {
+		t.Error("even_length should pass for a 4-character string")
+	}
+	if runRule(reflect.ValueOf("abc"), "even_length", "") {
+		t.Error("even_length should fail for a 3-character string")
+	}
+}
@@ -0,0 +1,245 @@
+// Package schemagen generates Go source files from JSON Schema (draft
+// 2020-12) documents. It produces a struct analogous to the hand-written
+// User type in examples/comparison, complete with `validate` tags, a
+// Process<Type>s slice filter and a Greet method, so hand-maintained
+// example types can be generated for arbitrary domains instead.
+package schemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// Schema is the subset of JSON Schema draft 2020-12 this generator
+// understands: an object type with named, typed properties.
+type Schema struct {
+	Title      string              `json:"title"`
+	Type       string              `json:"type"`
+	Properties map[string]Property `json:"properties"`
+	Required   []string            `json:"required"`
+}
+
+// Property is a single JSON Schema property definition.
+type Property struct {
+	Type    string   `json:"type"`
+	Format  string   `json:"format"`
+	Minimum *float64 `json:"minimum"`
+	Maximum *float64 `json:"maximum"`
+}
+
+// Options configures a single generation run.
+type Options struct {
+	Package       string   // -pkg
+	TopLevelName  string   // -top-level-name, overrides Schema.Title
+	HeaderComment []string // -comment, repeatable; prepended as file-header comments
+}
+
+// ParseSchema decodes a JSON Schema document.
+func ParseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("schemagen: parse schema: %w", err)
+	}
+	return &s, nil
+}
+
+// Generate renders a Go source file for s: a struct named after
+// opts.TopLevelName (falling back to s.Title), a Greet method and a
+// Process<Type>s slice filter, matching the shape of the hand-written User
+// example.
+func Generate(s *Schema, opts Options) (*jen.File, error) {
+	typeName := opts.TopLevelName
+	if typeName == "" {
+		typeName = s.Title
+	}
+	if typeName == "" {
+		return nil, fmt.Errorf("schemagen: schema has no title and no -top-level-name was given")
+	}
+
+	f := jen.NewFile(opts.Package)
+	for _, line := range opts.HeaderComment {
+		f.HeaderComment(line)
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]jen.Code, 0, len(names))
+	for _, name := range names {
+		fieldName := exportedName(name)
+		prop := s.Properties[name]
+		fields = append(fields, jen.Id(fieldName).Id(goType(prop)).Tag(map[string]string{
+			"json":     name,
+			"validate": validateTag(prop, required[name]),
+		}))
+	}
+	f.Type().Id(typeName).Struct(fields...)
+
+	greetField, ok := nameField(s, names)
+	if !ok {
+		return nil, fmt.Errorf("schemagen: schema %q has no string property for Greet to interpolate", typeName)
+	}
+	f.Func().Params(jen.Id("v").Op("*").Id(typeName)).Id("Greet").Params().String().Block(
+		jen.Return(jen.Qual("fmt", "Sprintf").Call(jen.Lit("Hello, %s!"), jen.Id("v").Dot(greetField))),
+	)
+
+	validFuncName := "isValid" + typeName
+	checks, needsEmail := validationChecks(s, names, required)
+	if needsEmail {
+		f.Var().Id("emailPattern").Op("=").Qual("regexp", "MustCompile").Call(jen.Lit(`^[^@\s]+@[^@\s]+\.[^@\s]+$`))
+	}
+	f.Comment(fmt.Sprintf("%s reports whether v satisfies the `validate` tags on %s.", validFuncName, typeName))
+	f.Func().Id(validFuncName).Params(jen.Id("v").Id(typeName)).Bool().Block(checks...)
+
+	processName := fmt.Sprintf("Process%ss", typeName)
+	f.Comment(fmt.Sprintf("%s filters valid %ss and returns their greetings.", processName, typeName))
+	f.Func().Id(processName).Params(jen.Id("items").Index().Id(typeName)).Index().String().Block(
+		jen.Var().Id("results").Index().String(),
+		jen.For(jen.List(jen.Id("_"), jen.Id("item")).Op(":=").Range().Id("items")).Block(
+			jen.If(jen.Id(validFuncName).Call(jen.Id("item"))).Block(
+				jen.Id("results").Op("=").Append(jen.Id("results"), jen.Id("item").Dot("Greet").Call()),
+			),
+		),
+		jen.Return(jen.Id("results")),
+	)
+
+	return f, nil
+}
+
+// validationChecks builds the early-return guards for isValid<Type>,
+// matching the same `required`, `email`, `gte`/`lte` rules emitted into the
+// `validate` tags. It reports whether any field needs the email regexp.
+func validationChecks(s *Schema, sortedNames []string, required map[string]bool) ([]jen.Code, bool) {
+	var checks []jen.Code
+	needsEmail := false
+	for _, name := range sortedNames {
+		fieldName := exportedName(name)
+		prop := s.Properties[name]
+		if required[name] {
+			checks = append(checks, jen.If(jen.Id("v").Dot(fieldName).Op("==").Add(zeroValue(prop))).Block(jen.Return(jen.False())))
+		}
+		if prop.Format == "email" {
+			needsEmail = true
+			// Like the `email` tag rule, only a non-empty value is format-checked;
+			// presence is left to `required`.
+			checks = append(checks, jen.If(jen.Id("v").Dot(fieldName).Op("!=").Lit("").Op("&&").Op("!").Id("emailPattern").Dot("MatchString").Call(jen.Id("v").Dot(fieldName))).Block(jen.Return(jen.False())))
+		}
+		if prop.Minimum != nil {
+			checks = append(checks, jen.If(jen.Id("v").Dot(fieldName).Op("<").Lit(*prop.Minimum)).Block(jen.Return(jen.False())))
+		}
+		if prop.Maximum != nil {
+			checks = append(checks, jen.If(jen.Id("v").Dot(fieldName).Op(">").Lit(*prop.Maximum)).Block(jen.Return(jen.False())))
+		}
+	}
+	checks = append(checks, jen.Return(jen.True()))
+	return checks, needsEmail
+}
+
+// zeroValue renders the Go zero-value literal for p's generated field type,
+// so the `required` check compares against the right kind of value.
+func zeroValue(p Property) *jen.Statement {
+	switch p.Type {
+	case "integer":
+		return jen.Lit(0)
+	case "number":
+		return jen.Lit(0.0)
+	case "boolean":
+		return jen.False()
+	default:
+		return jen.Lit("")
+	}
+}
+
+// exportedName turns a JSON Schema property name (snake_case or
+// camelCase) into an exported Go field name.
+func exportedName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' })
+	var b strings.Builder
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if b.Len() == 0 {
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+	return b.String()
+}
+
+func goType(p Property) string {
+	switch p.Type {
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// validateTag maps the schema property's constraints onto the same
+// `validate:"..."` vocabulary ValidateUser understands.
+func validateTag(p Property, required bool) string {
+	var rules []string
+	if required {
+		rules = append(rules, "required")
+	}
+	if p.Format == "email" {
+		rules = append(rules, "email")
+	}
+	if p.Minimum != nil {
+		rules = append(rules, fmt.Sprintf("gte=%s", trimFloat(*p.Minimum)))
+	}
+	if p.Maximum != nil {
+		rules = append(rules, fmt.Sprintf("lte=%s", trimFloat(*p.Maximum)))
+	}
+	return strings.Join(rules, ",")
+}
+
+func trimFloat(f float64) string {
+	s := fmt.Sprintf("%g", f)
+	return s
+}
+
+// nameField picks the property Greet should interpolate: a property named
+// "name" (compared case-insensitively), falling back to one whose name
+// contains "name" (e.g. full_name, first_name), then the first string-typed
+// field, each in alphabetical order of property names. It reports false if the schema declares no string property at
+// all, since there is then no field Greet could interpolate.
+func nameField(s *Schema, sortedNames []string) (string, bool) {
+	for _, name := range sortedNames {
+		if strings.EqualFold(name, "name") && isStringish(s.Properties[name]) {
+			return exportedName(name), true
+		}
+	}
+	for _, name := range sortedNames {
+		if strings.Contains(strings.ToLower(name), "name") && isStringish(s.Properties[name]) {
+			return exportedName(name), true
+		}
+	}
+	for _, name := range sortedNames {
+		if isStringish(s.Properties[name]) {
+			return exportedName(name), true
+		}
+	}
+	return "", false
+}
+
+func isStringish(p Property) bool {
+	return p.Type == "" || p.Type == "string"
+}
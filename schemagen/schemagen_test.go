@@ -0,0 +1,65 @@
+package schemagen
+
+import (
+	"strings"
+	"testing"
+)
+
+const customerSchema = `{
+	"title": "Customer",
+	"type": "object",
+	"properties": {
+		"email": {"type": "string", "format": "email"},
+		"full_name": {"type": "string"},
+		"age": {"type": "integer", "minimum": 0, "maximum": 150}
+	},
+	"required": ["full_name", "email"]
+}`
+
+func TestGenerateProducesValidFilterAndNameField(t *testing.T) {
+	schema, err := ParseSchema([]byte(customerSchema))
+	if err != nil {
+		t.Fatalf("ParseSchema: %v", err)
+	}
+
+	file, err := Generate(schema, Options{Package: "gentest"})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := file.Render(&buf); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	src := buf.String()
+
+	if !strings.Contains(src, "v.FullName") {
+		t.Errorf("Greet should interpolate the full_name field, got:\n%s", src)
+	}
+	if !strings.Contains(src, "func isValidCustomer(v Customer) bool") {
+		t.Errorf("expected a generated isValidCustomer validity gate, got:\n%s", src)
+	}
+	if !strings.Contains(src, "if isValidCustomer(item)") {
+		t.Errorf("ProcessCustomers should filter through isValidCustomer, got:\n%s", src)
+	}
+}
+
+func TestGenerateErrorsWithoutStringProperty(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{
+		"title": "Metric",
+		"type": "object",
+		"properties": {
+			"count": {"type": "integer"},
+			"ratio": {"type": "number"}
+		},
+		"required": ["count"]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseSchema: %v", err)
+	}
+
+	_, err = Generate(schema, Options{Package: "gentest"})
+	if err == nil {
+		t.Fatal("expected Generate to error when no string property exists for Greet to interpolate")
+	}
+}